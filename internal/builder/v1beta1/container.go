@@ -89,6 +89,25 @@ func Resources(ops ...ResourceRequirementsOp) ContainerOp {
 	}
 }
 
+// StepTemplateResources sets the specified requests and limits as the ResourceRequirements
+// of the Container, to be used with TaskStepTemplate as the baseline for every step.
+func StepTemplateResources(requests, limits corev1.ResourceList) ContainerOp {
+	return func(c *corev1.Container) {
+		c.Resources = corev1.ResourceRequirements{
+			Requests: requests,
+			Limits:   limits,
+		}
+	}
+}
+
+// StepTemplateImagePullPolicy sets the ImagePullPolicy of the Container, to be used with
+// TaskStepTemplate as the default pull policy of every step.
+func StepTemplateImagePullPolicy(p corev1.PullPolicy) ContainerOp {
+	return func(c *corev1.Container) {
+		c.ImagePullPolicy = p
+	}
+}
+
 // Limits adds Limits to the ResourceRequirements.
 func Limits(ops ...ResourceListOp) ResourceRequirementsOp {
 	return func(rr *corev1.ResourceRequirements) {
@@ -17,6 +17,7 @@ limitations under the License.
 package builder
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -27,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 // PipelineOp is an operation which modify a Pipeline struct.
@@ -254,6 +256,18 @@ func PipelineTaskRefKind(kind v1beta1.TaskKind) PipelineTaskOp {
 	}
 }
 
+// PipelineTaskCustomRef sets the PipelineTask's TaskRef to the custom task with the
+// specified apiVersion, kind and name. Such a PipelineTask is run as a Run instead of a TaskRun.
+func PipelineTaskCustomRef(apiVersion, kind, name string) PipelineTaskOp {
+	return func(pt *v1beta1.PipelineTask) {
+		pt.TaskRef = &v1beta1.TaskRef{
+			APIVersion: apiVersion,
+			Kind:       v1beta1.TaskKind(kind),
+			Name:       name,
+		}
+	}
+}
+
 // PipelineTaskParam adds a ResourceParam, with specified name and value, to the PipelineTask.
 func PipelineTaskParam(name string, value string, additionalValues ...string) PipelineTaskOp {
 	return func(pt *v1beta1.PipelineTask) {
@@ -264,6 +278,12 @@ func PipelineTaskParam(name string, value string, additionalValues ...string) Pi
 	}
 }
 
+// PipelineTaskParamFromResult adds a param, with specified name, to the PipelineTask whose
+// value is the result with the specified name of the PipelineTask with the specified name.
+func PipelineTaskParamFromResult(paramName, taskName, resultName string) PipelineTaskOp {
+	return PipelineTaskParam(paramName, fmt.Sprintf("$(tasks.%s.results.%s)", taskName, resultName))
+}
+
 // From will update the provided PipelineTaskInputResource to indicate that it
 // should come from tasks.
 func From(tasks ...string) PipelineTaskInputResourceOp {
@@ -360,6 +380,14 @@ func PipelineTaskWhenExpression(input string, operator selection.Operator, value
 	}
 }
 
+// PipelineTaskWhenExpressions adds the specified WhenExpressions to the PipelineTask.
+// All of them must evaluate to true for the PipelineTask to be executed.
+func PipelineTaskWhenExpressions(exprs ...v1beta1.WhenExpression) PipelineTaskOp {
+	return func(pt *v1beta1.PipelineTask) {
+		pt.WhenExpressions = append(pt.WhenExpressions, exprs...)
+	}
+}
+
 // PipelineTaskWorkspaceBinding adds a workspace with the specified name, workspace and subpath on a PipelineTask.
 func PipelineTaskWorkspaceBinding(name, workspace, subPath string) PipelineTaskOp {
 	return func(pt *v1beta1.PipelineTask) {
@@ -502,6 +530,17 @@ func PipelineTaskRunSpecs(taskRunSpecs []v1beta1.PipelineTaskRunSpec) PipelineRu
 	}
 }
 
+// PipelineRunTaskPodTemplate adds a TaskRunSpec to the PipelineRunSpec overriding the
+// PodTemplate of the TaskRun created for the PipelineTask with the specified name.
+func PipelineRunTaskPodTemplate(pipelineTask string, template *pod.Template) PipelineRunSpecOp {
+	return func(prs *v1beta1.PipelineRunSpec) {
+		prs.TaskRunSpecs = append(prs.TaskRunSpecs, v1beta1.PipelineTaskRunSpec{
+			PipelineTaskName: pipelineTask,
+			TaskPodTemplate:  template,
+		})
+	}
+}
+
 // PipelineRunParam add a param, with specified name and value, to the PipelineRunSpec.
 func PipelineRunParam(name string, value string, additionalValues ...string) PipelineRunSpecOp {
 	return func(prs *v1beta1.PipelineRunSpec) {
@@ -512,6 +551,20 @@ func PipelineRunParam(name string, value string, additionalValues ...string) Pip
 	}
 }
 
+// PipelineRunParamArray add an array param, with specified name and values, to the PipelineRunSpec.
+// Unlike PipelineRunParam, the value is an array even when a single value is passed.
+func PipelineRunParamArray(name string, values ...string) PipelineRunSpecOp {
+	return func(prs *v1beta1.PipelineRunSpec) {
+		prs.Params = append(prs.Params, v1beta1.Param{
+			Name: name,
+			Value: v1beta1.ArrayOrString{
+				Type:     v1beta1.ParamTypeArray,
+				ArrayVal: values,
+			},
+		})
+	}
+}
+
 // PipelineRunTimeout sets the timeout to the PipelineRunSpec.
 func PipelineRunTimeout(duration time.Duration) PipelineRunSpecOp {
 	return func(prs *v1beta1.PipelineRunSpec) {
@@ -597,6 +650,24 @@ func PipelineRunTaskRunsStatus(taskRunName string, status *v1beta1.PipelineRunTa
 	}
 }
 
+// PipelineRunStatusTaskRunFailed records a TaskRun for the given pipeline task
+// in the PipelineRunStatus with a failed Succeeded condition.
+func PipelineRunStatusTaskRunFailed(pipelineTask, taskRunName, reason, message string) PipelineRunStatusOp {
+	return PipelineRunTaskRunsStatus(taskRunName, &v1beta1.PipelineRunTaskRunStatus{
+		PipelineTaskName: pipelineTask,
+		Status: &v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  reason,
+					Message: message,
+				}},
+			},
+		},
+	})
+}
+
 // PipelineWorkspaceDeclaration adds a Workspace to the workspaces listed in the pipeline spec.
 func PipelineWorkspaceDeclaration(names ...string) PipelineSpecOp {
 	return func(spec *v1beta1.PipelineSpec) {
@@ -631,3 +702,16 @@ func PipelineRunWorkspaceBindingVolumeClaimTemplate(name string, claimName strin
 		})
 	}
 }
+
+// PipelineRunWorkspaceBindingPVC adds a PersistentVolumeClaim Workspace to the workspaces of a pipelineRun spec.
+func PipelineRunWorkspaceBindingPVC(name string, claimName string, subPath string) PipelineRunSpecOp {
+	return func(spec *v1beta1.PipelineRunSpec) {
+		spec.Workspaces = append(spec.Workspaces, v1beta1.WorkspaceBinding{
+			Name:    name,
+			SubPath: subPath,
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		})
+	}
+}
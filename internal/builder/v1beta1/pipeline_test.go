@@ -25,6 +25,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
@@ -445,6 +446,230 @@ func TestPipelineRunWithFinalTask(t *testing.T) {
 	}
 }
 
+func TestPipelineTaskParamFromResult(t *testing.T) {
+	pipeline := tb.Pipeline("tomatoes", tb.PipelineSpec(
+		tb.PipelineTask("fetch", "fetch-task"),
+		tb.PipelineTask("build", "build-task",
+			tb.PipelineTaskParamFromResult("commit", "fetch", "commit-sha"),
+		),
+	))
+	expectedParams := []v1beta1.Param{{
+		Name:  "commit",
+		Value: *v1beta1.NewArrayOrString("$(tasks.fetch.results.commit-sha)"),
+	}}
+	build := pipeline.Spec.Tasks[1]
+	if d := cmp.Diff(expectedParams, build.Params); d != "" {
+		t.Errorf("Params diff -want, +got: %v", d)
+	}
+	if d := cmp.Diff([]string{"fetch"}, build.Deps()); d != "" {
+		t.Errorf("Deps diff -want, +got: %v", d)
+	}
+}
+
+func TestPipelineTaskCustomRef(t *testing.T) {
+	pipeline := tb.Pipeline("tomatoes", tb.PipelineSpec(
+		tb.PipelineTask("wait", "", tb.PipelineTaskCustomRef("example.dev/v0", "Wait", "wait-a-bit")),
+	))
+	expectedTaskRef := &v1beta1.TaskRef{
+		APIVersion: "example.dev/v0",
+		Kind:       "Wait",
+		Name:       "wait-a-bit",
+	}
+	if d := cmp.Diff(expectedTaskRef, pipeline.Spec.Tasks[0].TaskRef); d != "" {
+		t.Fatalf("TaskRef diff -want, +got: %v", d)
+	}
+}
+
+func TestPipelineTaskTimeout(t *testing.T) {
+	pipeline := tb.Pipeline("tomatoes", tb.PipelineSpec(
+		tb.PipelineTask("build", "build-task", tb.PipelineTaskTimeout(5*time.Minute)),
+	))
+	expectedTimeout := &metav1.Duration{Duration: 5 * time.Minute}
+	if d := cmp.Diff(expectedTimeout, pipeline.Spec.Tasks[0].Timeout); d != "" {
+		t.Fatalf("Timeout diff -want, +got: %v", d)
+	}
+}
+
+func TestPipelineTaskWorkspaceBinding(t *testing.T) {
+	pipeline := tb.Pipeline("tomatoes", tb.PipelineSpec(
+		tb.PipelineWorkspaceDeclaration("shared-data"),
+		tb.PipelineTask("build", "build-task",
+			tb.PipelineTaskWorkspaceBinding("source", "shared-data", ""),
+		),
+	))
+	expectedWorkspaces := []v1beta1.PipelineWorkspaceDeclaration{{Name: "shared-data"}}
+	if d := cmp.Diff(expectedWorkspaces, pipeline.Spec.Workspaces); d != "" {
+		t.Errorf("Workspaces diff -want, +got: %v", d)
+	}
+	expectedBindings := []v1beta1.WorkspacePipelineTaskBinding{{
+		Name:      "source",
+		Workspace: "shared-data",
+	}}
+	if d := cmp.Diff(expectedBindings, pipeline.Spec.Tasks[0].Workspaces); d != "" {
+		t.Errorf("PipelineTask workspaces diff -want, +got: %v", d)
+	}
+}
+
+func TestPipelineRunParamArray(t *testing.T) {
+	pipelineRun := tb.PipelineRun("pear", tb.PipelineRunSpec("tomatoes",
+		tb.PipelineRunParamArray("platforms", "linux/amd64", "linux/arm64", "linux/s390x"),
+		tb.PipelineRunParamArray("single", "only"),
+	))
+	expectedParams := []v1beta1.Param{{
+		Name: "platforms",
+		Value: v1beta1.ArrayOrString{
+			Type:     v1beta1.ParamTypeArray,
+			ArrayVal: []string{"linux/amd64", "linux/arm64", "linux/s390x"},
+		},
+	}, {
+		Name: "single",
+		Value: v1beta1.ArrayOrString{
+			Type:     v1beta1.ParamTypeArray,
+			ArrayVal: []string{"only"},
+		},
+	}}
+	if d := cmp.Diff(expectedParams, pipelineRun.Spec.Params); d != "" {
+		t.Fatalf("Params diff -want, +got: %v", d)
+	}
+}
+
+func TestPipelineTaskRetries(t *testing.T) {
+	pipeline := tb.Pipeline("tomatoes", tb.PipelineSpec(
+		tb.PipelineTask("flaky", "flaky-task", tb.Retries(3)),
+	))
+	if got := pipeline.Spec.Tasks[0].Retries; got != 3 {
+		t.Fatalf("expected 3 retries but got %d", got)
+	}
+}
+
+func TestPipelineTaskRunAfterCycle(t *testing.T) {
+	pipeline := tb.Pipeline("tomatoes", tb.PipelineSpec(
+		tb.PipelineTask("a", "task", tb.RunAfter("b")),
+		tb.PipelineTask("b", "task", tb.RunAfter("a")),
+	))
+	// The builder does not reject the cycle, detecting it is up to the DAG.
+	tasks := v1beta1.PipelineTaskList(pipeline.Spec.Tasks)
+	if _, err := dag.Build(tasks, tasks.Deps()); err == nil {
+		t.Fatal("expected an error building a DAG with a cycle")
+	}
+}
+
+func TestPipelineRunTaskPodTemplate(t *testing.T) {
+	gpuTemplate := &pod.Template{
+		NodeSelector: map[string]string{"accelerator": "gpu"},
+	}
+	pipelineRun := tb.PipelineRun("pear", tb.PipelineRunSpec("tomatoes",
+		tb.PipelineRunNodeSelector(map[string]string{"pool": "default"}),
+		tb.PipelineRunTaskPodTemplate("train", gpuTemplate),
+	))
+	expectedPodTemplate := &pod.Template{
+		NodeSelector: map[string]string{"pool": "default"},
+	}
+	if d := cmp.Diff(expectedPodTemplate, pipelineRun.Spec.PodTemplate); d != "" {
+		t.Errorf("PodTemplate diff -want, +got: %v", d)
+	}
+	expectedTaskRunSpecs := []v1beta1.PipelineTaskRunSpec{{
+		PipelineTaskName: "train",
+		TaskPodTemplate:  gpuTemplate,
+	}}
+	if d := cmp.Diff(expectedTaskRunSpecs, pipelineRun.Spec.TaskRunSpecs); d != "" {
+		t.Errorf("TaskRunSpecs diff -want, +got: %v", d)
+	}
+}
+
+func TestPipelineTaskWhenExpressions(t *testing.T) {
+	branchIsMain := v1beta1.WhenExpression{Input: "main", Operator: selection.In, Values: []string{"main"}}
+	notDraft := v1beta1.WhenExpression{Input: "true", Operator: selection.NotIn, Values: []string{"true"}}
+	pipeline := tb.Pipeline("tomatoes", tb.PipelineSpec(
+		tb.PipelineTask("deploy", "deploy-task", tb.PipelineTaskWhenExpressions(branchIsMain, notDraft)),
+	))
+	whenExpressions := pipeline.Spec.Tasks[0].WhenExpressions
+	if d := cmp.Diff(v1beta1.WhenExpressions{branchIsMain, notDraft}, whenExpressions); d != "" {
+		t.Fatalf("WhenExpressions diff -want, +got: %v", d)
+	}
+	// branchIsMain is true but notDraft is false, so the task must be skipped.
+	if whenExpressions.AllowsExecution() {
+		t.Error("expected the when expressions not to allow execution")
+	}
+	if !whenExpressions[:1].AllowsExecution() {
+		t.Error("expected the first when expression to allow execution")
+	}
+}
+
+func TestPipelineRunStatusTaskRunFailed(t *testing.T) {
+	pr := tb.PipelineRun("pear", tb.PipelineRunStatus(
+		tb.PipelineRunStatusTaskRunFailed("unit-test", "pear-unit-test", "Failed", "step build failed"),
+	))
+	expected := map[string]*v1beta1.PipelineRunTaskRunStatus{
+		"pear-unit-test": {
+			PipelineTaskName: "unit-test",
+			Status: &v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: []apis.Condition{{
+						Type:    apis.ConditionSucceeded,
+						Status:  corev1.ConditionFalse,
+						Reason:  "Failed",
+						Message: "step build failed",
+					}},
+				},
+			},
+		},
+	}
+	if d := cmp.Diff(expected, pr.Status.TaskRuns); d != "" {
+		t.Fatalf("TaskRuns status diff -want, +got: %v", d)
+	}
+}
+
+func TestPipelineRunParamOverridesPipelineDefault(t *testing.T) {
+	pr := tb.PipelineRun("pear", tb.PipelineRunSpec("",
+		tb.PipelineRunPipelineSpec(
+			tb.PipelineParamSpec("revision", v1beta1.ParamTypeString, tb.ParamSpecDefault("main")),
+		),
+		tb.PipelineRunParam("revision", "v0.1.0"),
+	))
+	expectedParamSpecs := []v1beta1.ParamSpec{{
+		Name:    "revision",
+		Type:    v1beta1.ParamTypeString,
+		Default: v1beta1.NewArrayOrString("main"),
+	}}
+	if d := cmp.Diff(expectedParamSpecs, pr.Spec.PipelineSpec.Params); d != "" {
+		t.Fatalf("ParamSpecs diff -want, +got: %v", d)
+	}
+	expectedParams := []v1beta1.Param{{
+		Name:  "revision",
+		Value: *v1beta1.NewArrayOrString("v0.1.0"),
+	}}
+	if d := cmp.Diff(expectedParams, pr.Spec.Params); d != "" {
+		t.Fatalf("Params diff -want, +got: %v", d)
+	}
+}
+
+func TestPipelineRunWorkspaceBindingPVC(t *testing.T) {
+	pr := tb.PipelineRun("pear", tb.PipelineRunSpec("",
+		tb.PipelineRunPipelineSpec(
+			tb.PipelineWorkspaceDeclaration("source"),
+			tb.PipelineTask("clone", "git-clone", tb.PipelineTaskWorkspaceBinding("output", "source", "")),
+			tb.PipelineTask("build", "kaniko", tb.PipelineTaskWorkspaceBinding("context", "source", "")),
+		),
+		tb.PipelineRunWorkspaceBindingPVC("source", "shared-claim", "checkout"),
+	))
+	expectedWorkspaces := []v1beta1.WorkspaceBinding{{
+		Name:    "source",
+		SubPath: "checkout",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: "shared-claim",
+		},
+	}}
+	if d := cmp.Diff(expectedWorkspaces, pr.Spec.Workspaces); d != "" {
+		t.Fatalf("Workspaces diff -want, +got: %v", d)
+	}
+	for _, pt := range pr.Spec.PipelineSpec.Tasks {
+		if len(pt.Workspaces) != 1 || pt.Workspaces[0].Workspace != "source" {
+			t.Errorf("expected pipeline task %q to bind workspace %q but got %v", pt.Name, "source", pt.Workspaces)
+		}
+	}
+}
+
 func getTaskSpec() v1beta1.TaskSpec {
 	return v1beta1.TaskSpec{
 		Steps: []v1beta1.Step{{Container: corev1.Container{
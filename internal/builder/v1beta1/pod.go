@@ -17,6 +17,7 @@ limitations under the License.
 package builder
 
 import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,6 +47,16 @@ func Pod(name string, ops ...PodOp) *corev1.Pod {
 	return pod
 }
 
+// ExpectedTaskRunPodLabels returns the labels the controller sets on the Pod of a
+// TaskRun with the specified name, referencing the Task with the specified name.
+// This is not an operation but a helper to write assertions on Pods.
+func ExpectedTaskRunPodLabels(taskRunName, taskName string) map[string]string {
+	return map[string]string{
+		pipeline.GroupName + pipeline.TaskLabelKey:    taskName,
+		pipeline.GroupName + pipeline.TaskRunLabelKey: taskRunName,
+	}
+}
+
 // PodNamespace sets the namespace on the Pod.
 func PodNamespace(namespace string) PodOp {
 	return func(t *corev1.Pod) {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	tb "github.com/tektoncd/pipeline/internal/builder/v1beta1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -126,3 +127,23 @@ func TestPod(t *testing.T) {
 		t.Fatalf("Pod diff -want, +got: %v", d)
 	}
 }
+
+func TestExpectedTaskRunPodLabels(t *testing.T) {
+	// The reconciler propagates the Task label onto the TaskRun, and the
+	// pod converter passes TaskRun labels through and adds the TaskRun label.
+	taskRun := tb.TaskRun("test-taskrun",
+		tb.TaskRunLabel("tekton.dev/task", "test-task"),
+		tb.TaskRunSpec(tb.TaskRunTaskRef("test-task")),
+	)
+	want := map[string]string{
+		"tekton.dev/task":    "test-task",
+		"tekton.dev/taskRun": "test-taskrun",
+	}
+	got := tb.ExpectedTaskRunPodLabels("test-taskrun", "test-task")
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Labels diff -want, +got: %v", d)
+	}
+	if d := cmp.Diff(podconvert.MakeLabels(taskRun), got); d != "" {
+		t.Errorf("Labels diff with controller labels -want, +got: %v", d)
+	}
+}
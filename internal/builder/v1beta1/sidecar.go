@@ -18,6 +18,36 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+// SidecarPort adds a named TCP container port to the Sidecar.
+func SidecarPort(name string, port int32) ContainerOp {
+	return func(c *corev1.Container) {
+		c.Ports = append(c.Ports, corev1.ContainerPort{
+			Name:          name,
+			ContainerPort: port,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+}
+
+// SidecarPreStopExec sets a PreStop lifecycle hook running the specified command on the Sidecar.
+func SidecarPreStopExec(command ...string) ContainerOp {
+	return func(c *corev1.Container) {
+		if c.Lifecycle == nil {
+			c.Lifecycle = &corev1.Lifecycle{}
+		}
+		c.Lifecycle.PreStop = &corev1.Handler{
+			Exec: &corev1.ExecAction{Command: command},
+		}
+	}
+}
+
+// SidecarTerminationMessagePolicy sets the TerminationMessagePolicy of the Sidecar.
+func SidecarTerminationMessagePolicy(policy corev1.TerminationMessagePolicy) ContainerOp {
+	return func(c *corev1.Container) {
+		c.TerminationMessagePolicy = policy
+	}
+}
+
 // SidecarStateName sets the name of the Sidecar for the SidecarState.
 func SidecarStateName(name string) SidecarStateOp {
 	return func(s *v1beta1.SidecarState) {
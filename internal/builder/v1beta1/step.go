@@ -14,6 +14,8 @@ limitations under the License.
 package builder
 
 import (
+	"fmt"
+
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -49,6 +51,14 @@ func StepArgs(args ...string) StepOp {
 	}
 }
 
+// StepArgsArrayParam adds an arg referencing every element of the named array param,
+// to be expanded into multiple args when the param is substituted.
+func StepArgsArrayParam(paramName string) StepOp {
+	return func(step *v1beta1.Step) {
+		step.Args = append(step.Args, fmt.Sprintf("$(params.%s[*])", paramName))
+	}
+}
+
 // StepEnvVar add an environment variable, with specified name and value, to the Container (step).
 func StepEnvVar(name, value string) StepOp {
 	return func(step *v1beta1.Step) {
@@ -80,9 +90,53 @@ func StepVolumeMount(name, mountPath string, ops ...VolumeMountOp) StepOp {
 	}
 }
 
+// StepVolumeDevice adds a raw block device, backed by the named volume, to the Step.
+func StepVolumeDevice(name, devicePath string) StepOp {
+	return func(step *v1beta1.Step) {
+		step.VolumeDevices = append(step.VolumeDevices, corev1.VolumeDevice{
+			Name:       name,
+			DevicePath: devicePath,
+		})
+	}
+}
+
 // StepScript sets the script to the Step.
 func StepScript(script string) StepOp {
 	return func(step *v1beta1.Step) {
 		step.Script = script
 	}
 }
+
+// StepTerminationMessagePath sets the TerminationMessagePath of the Step.
+func StepTerminationMessagePath(path string) StepOp {
+	return func(step *v1beta1.Step) {
+		step.TerminationMessagePath = path
+	}
+}
+
+// StepResources adds ResourceRequirements to the Step.
+func StepResources(ops ...ResourceRequirementsOp) StepOp {
+	return func(step *v1beta1.Step) {
+		rr := &corev1.ResourceRequirements{}
+		for _, op := range ops {
+			op(rr)
+		}
+		step.Resources = *rr
+	}
+}
+
+// StepResourcesRequests sets only the resource Requests of the Step, leaving Limits unset.
+func StepResourcesRequests(requests corev1.ResourceList) StepOp {
+	return func(step *v1beta1.Step) {
+		step.Resources = corev1.ResourceRequirements{
+			Requests: requests,
+		}
+	}
+}
+
+// StepImagePullPolicy sets the ImagePullPolicy of the Step.
+func StepImagePullPolicy(policy corev1.PullPolicy) StepOp {
+	return func(step *v1beta1.Step) {
+		step.ImagePullPolicy = policy
+	}
+}
@@ -17,13 +17,18 @@ limitations under the License.
 package builder
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/workspace"
 	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -180,6 +185,16 @@ func Step(image string, ops ...StepOp) TaskSpecOp {
 	}
 }
 
+// TaskOrderedSteps adds one step per image to the TaskSpec, in order, named
+// step-0, step-1, and so on.
+func TaskOrderedSteps(images ...string) TaskSpecOp {
+	return func(spec *v1beta1.TaskSpec) {
+		for i, image := range images {
+			Step(image, StepName(fmt.Sprintf("step-%d", i)))(spec)
+		}
+	}
+}
+
 // Sidecar adds a sidecar container with the specified name and image to the TaskSpec.
 // Any number of Container modifier can be passed to transform it.
 func Sidecar(name, image string, ops ...ContainerOp) TaskSpecOp {
@@ -207,6 +222,17 @@ func TaskWorkspace(name, desc, mountPath string, readOnly bool) TaskSpecOp {
 	}
 }
 
+// TaskWorkspaceDefaultMount adds a workspace declaration without a mount path, so the
+// default /workspace/<name> is used when the workspace is mounted.
+func TaskWorkspaceDefaultMount(name, desc string) TaskSpecOp {
+	return func(spec *v1beta1.TaskSpec) {
+		spec.Workspaces = append(spec.Workspaces, v1beta1.WorkspaceDeclaration{
+			Name:        name,
+			Description: desc,
+		})
+	}
+}
+
 // TaskStepTemplate adds a base container for all steps in the task.
 func TaskStepTemplate(ops ...ContainerOp) TaskSpecOp {
 	return func(spec *v1beta1.TaskSpec) {
@@ -230,6 +256,31 @@ func TaskVolume(name string, ops ...VolumeOp) TaskSpecOp {
 	}
 }
 
+// TaskScratchVolume adds an emptyDir volume with the specified name to the TaskSpec
+// and mounts it at mountPath in every step, and in the step template if one is set.
+// Only steps (and step template) already present on the TaskSpec are modified, so it
+// should be passed after the Step modifiers that need the mount.
+func TaskScratchVolume(name, mountPath string) TaskSpecOp {
+	return func(spec *v1beta1.TaskSpec) {
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+		mount := corev1.VolumeMount{
+			Name:      name,
+			MountPath: mountPath,
+		}
+		for i := range spec.Steps {
+			spec.Steps[i].VolumeMounts = append(spec.Steps[i].VolumeMounts, mount)
+		}
+		if spec.StepTemplate != nil {
+			spec.StepTemplate.VolumeMounts = append(spec.StepTemplate.VolumeMounts, mount)
+		}
+	}
+}
+
 // VolumeSource sets the VolumeSource to the Volume.
 func VolumeSource(s corev1.VolumeSource) VolumeOp {
 	return func(v *corev1.Volume) {
@@ -237,6 +288,15 @@ func VolumeSource(s corev1.VolumeSource) VolumeOp {
 	}
 }
 
+// VolumeSourceSecret sets a Secret VolumeSource, for the Secret with the specified name, to the Volume.
+func VolumeSourceSecret(secretName string) VolumeOp {
+	return func(v *corev1.Volume) {
+		v.VolumeSource = corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+		}
+	}
+}
+
 // TaskParam sets the Params to the TaskSpec
 func TaskParam(name string, pt v1beta1.ParamType, ops ...ParamSpecOp) TaskSpecOp {
 	return func(spec *v1beta1.TaskSpec) {
@@ -248,6 +308,15 @@ func TaskParam(name string, pt v1beta1.ParamType, ops ...ParamSpecOp) TaskSpecOp
 	}
 }
 
+// TaskWithDefaultParam creates a Task with the specified name declaring a string param
+// with the specified default value. It is meant to be used with TaskRunOverrideParam
+// to test that TaskRun params take precedence over Task defaults.
+func TaskWithDefaultParam(taskName, param, dflt string) *v1beta1.Task {
+	return Task(taskName, TaskSpec(
+		TaskParam(param, v1beta1.ParamTypeString, ParamSpecDefault(dflt)),
+	))
+}
+
 // TaskResources sets the Resources to the TaskSpec
 func TaskResources(ops ...TaskResourcesOp) TaskSpecOp {
 	return func(spec *v1beta1.TaskSpec) {
@@ -365,6 +434,47 @@ func StatusCondition(condition apis.Condition) TaskRunStatusOp {
 	}
 }
 
+// TaskRunPodDeleted sets the Pod name to the TaskRunStatus and adds a failed
+// Succeeded condition with reason "PodDeleted", as for a TaskRun whose Pod vanished.
+func TaskRunPodDeleted(podName string) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		s.PodName = podName
+		s.Conditions = append(s.Conditions, apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: "PodDeleted",
+		})
+	}
+}
+
+// TaskRunNodeLost sets the Pod name to the TaskRunStatus and adds an unknown
+// Succeeded condition with reason "NodeLost", as for a TaskRun whose Pod was
+// evicted from a lost node and may still be recreated.
+func TaskRunNodeLost(podName string) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		s.PodName = podName
+		s.Conditions = append(s.Conditions, apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+			Reason: "NodeLost",
+		})
+	}
+}
+
+// TaskRunPendingResourceQuota adds an unknown Succeeded condition, with reason
+// "ExceededResourceQuota" and the specified message, to the TaskRunStatus, as for a
+// TaskRun whose Pod cannot be created yet because of a ResourceQuota.
+func TaskRunPendingResourceQuota(message string) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		s.Conditions = append(s.Conditions, apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  podconvert.ReasonExceededResourceQuota,
+			Message: message,
+		})
+	}
+}
+
 // TaskRunResult adds a result with the specified name and value to the TaskRunStatus.
 func TaskRunResult(name, value string) TaskRunStatusOp {
 	return func(s *v1beta1.TaskRunStatus) {
@@ -375,6 +485,16 @@ func TaskRunResult(name, value string) TaskRunStatusOp {
 	}
 }
 
+// TaskRunResultsOrdered adds a result to the TaskRunStatus for each name and value pair,
+// in the order they are passed.
+func TaskRunResultsOrdered(pairs ...[2]string) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		for _, pair := range pairs {
+			TaskRunResult(pair[0], pair[1])(s)
+		}
+	}
+}
+
 // Retry adds a RetriesStatus (TaskRunStatus) to the TaskRunStatus.
 func Retry(retry v1beta1.TaskRunStatus) TaskRunStatusOp {
 	return func(s *v1beta1.TaskRunStatus) {
@@ -382,6 +502,41 @@ func Retry(retry v1beta1.TaskRunStatus) TaskRunStatusOp {
 	}
 }
 
+// RetryWithPod adds a failed RetriesStatus, with the specified pod name and reason,
+// to the TaskRunStatus.
+func RetryWithPod(podName, reason string) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		retry := v1beta1.TaskRunStatus{}
+		retry.Conditions = append(retry.Conditions, apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: reason,
+		})
+		retry.PodName = podName
+		s.RetriesStatus = append(s.RetriesStatus, retry)
+	}
+}
+
+// TaskRunRetriedThenSucceeded adds a failed RetriesStatus, with the specified reason,
+// to the TaskRunStatus and marks it as succeeded at the specified completion time.
+func TaskRunRetriedThenSucceeded(failReason string, completionTime time.Time) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		retry := v1beta1.TaskRunStatus{}
+		retry.Conditions = append(retry.Conditions, apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: failReason,
+		})
+		s.RetriesStatus = append(s.RetriesStatus, retry)
+		s.Conditions = append(s.Conditions, apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+			Reason: v1beta1.TaskRunReasonSuccessful.String(),
+		})
+		s.CompletionTime = &metav1.Time{Time: completionTime}
+	}
+}
+
 // StepState adds a StepState to the TaskRunStatus.
 func StepState(ops ...StepStateOp) TaskRunStatusOp {
 	return func(s *v1beta1.TaskRunStatus) {
@@ -393,6 +548,16 @@ func StepState(ops ...StepStateOp) TaskRunStatusOp {
 	}
 }
 
+// TaskRunStatusStepsMatching adds a StepState, terminated with exit code 0, to the
+// TaskRunStatus for each of the specified step names, in order.
+func TaskRunStatusStepsMatching(stepNames ...string) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		for _, name := range stepNames {
+			StepState(StepStateName(name), StateTerminated(0))(s)
+		}
+	}
+}
+
 // SidecarState adds a SidecarState to the TaskRunStatus.
 func SidecarState(ops ...SidecarStateOp) TaskRunStatusOp {
 	return func(s *v1beta1.TaskRunStatus) {
@@ -404,6 +569,58 @@ func SidecarState(ops ...SidecarStateOp) TaskRunStatusOp {
 	}
 }
 
+// TaskRunStatusTaskSpec sets the resolved TaskSpec to the TaskRunStatus.
+// Any number of TaskSpec modifier can be passed to create/modify it.
+func TaskRunStatusTaskSpec(ops ...TaskSpecOp) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		if s.TaskSpec == nil {
+			s.TaskSpec = &v1beta1.TaskSpec{}
+		}
+		for _, op := range ops {
+			op(s.TaskSpec)
+		}
+	}
+}
+
+// TaskRunStatusTaskSpecWorkspaces adds a workspace declaration, with specified name, to
+// the resolved TaskSpec of the TaskRunStatus for each of the specified names.
+func TaskRunStatusTaskSpecWorkspaces(names ...string) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		if s.TaskSpec == nil {
+			s.TaskSpec = &v1beta1.TaskSpec{}
+		}
+		for _, name := range names {
+			s.TaskSpec.Workspaces = append(s.TaskSpec.Workspaces, v1beta1.WorkspaceDeclaration{Name: name})
+		}
+	}
+}
+
+// TaskRunStatusResolvedImages adds, for each step name in stepImages, a step with the
+// given (digest-pinned) image to the resolved TaskSpec and a StepState recording the
+// same image as its ImageID. Steps are added in step name order.
+func TaskRunStatusResolvedImages(stepImages map[string]string) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		names := make([]string, 0, len(stepImages))
+		for name := range stepImages {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if s.TaskSpec == nil {
+			s.TaskSpec = &v1beta1.TaskSpec{}
+		}
+		for _, name := range names {
+			s.TaskSpec.Steps = append(s.TaskSpec.Steps, v1beta1.Step{Container: corev1.Container{
+				Name:  name,
+				Image: stepImages[name],
+			}})
+			s.Steps = append(s.Steps, v1beta1.StepState{
+				Name:    name,
+				ImageID: stepImages[name],
+			})
+		}
+	}
+}
+
 // TaskRunStartTime sets the start time to the TaskRunStatus.
 func TaskRunStartTime(startTime time.Time) TaskRunStatusOp {
 	return func(s *v1beta1.TaskRunStatus) {
@@ -418,6 +635,19 @@ func TaskRunCompletionTime(completionTime time.Time) TaskRunStatusOp {
 	}
 }
 
+// TaskRunCancelledStatus marks the TaskRunStatus as cancelled, with a failed Succeeded
+// condition with reason "TaskRunCancelled", and sets the completion time.
+func TaskRunCancelledStatus(completionTime time.Time) TaskRunStatusOp {
+	return func(s *v1beta1.TaskRunStatus) {
+		s.Conditions = append(s.Conditions, apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: v1beta1.TaskRunReasonCancelled.String(),
+		})
+		s.CompletionTime = &metav1.Time{Time: completionTime}
+	}
+}
+
 // TaskRunCloudEvent adds an event to the TaskRunStatus.
 func TaskRunCloudEvent(target, error string, retryCount int32, condition v1beta1.CloudEventCondition) TaskRunStatusOp {
 	return func(s *v1beta1.TaskRunStatus) {
@@ -458,6 +688,26 @@ func TaskRunNodeSelector(values map[string]string) TaskRunSpecOp {
 	}
 }
 
+// TaskRunPodTemplateEnableServiceLinks sets EnableServiceLinks on the PodTemplate of the TaskRunSpec.
+func TaskRunPodTemplateEnableServiceLinks(enabled bool) TaskRunSpecOp {
+	return func(spec *v1beta1.TaskRunSpec) {
+		if spec.PodTemplate == nil {
+			spec.PodTemplate = &pod.Template{}
+		}
+		spec.PodTemplate.EnableServiceLinks = &enabled
+	}
+}
+
+// TaskRunPodTemplateAutomountSAToken sets AutomountServiceAccountToken on the PodTemplate of the TaskRunSpec.
+func TaskRunPodTemplateAutomountSAToken(enabled bool) TaskRunSpecOp {
+	return func(spec *v1beta1.TaskRunSpec) {
+		if spec.PodTemplate == nil {
+			spec.PodTemplate = &pod.Template{}
+		}
+		spec.PodTemplate.AutomountServiceAccountToken = &enabled
+	}
+}
+
 // StateTerminated sets Terminated to the StepState.
 func StateTerminated(exitcode int) StepStateOp {
 	return func(s *v1beta1.StepState) {
@@ -467,6 +717,34 @@ func StateTerminated(exitcode int) StepStateOp {
 	}
 }
 
+// StepStateOOMKilled sets the name of the step and a Terminated state, with reason
+// "OOMKilled" and exit code 137, to the StepState.
+func StepStateOOMKilled(name string) StepStateOp {
+	return func(s *v1beta1.StepState) {
+		s.Name = name
+		s.ContainerState = corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 137,
+				Reason:   "OOMKilled",
+			},
+		}
+	}
+}
+
+// StepStateWaitingReason sets the name of the step and a Waiting state with
+// the given reason and message, e.g. "ImagePullBackOff".
+func StepStateWaitingReason(name, reason, message string) StepStateOp {
+	return func(s *v1beta1.StepState) {
+		s.Name = name
+		s.ContainerState = corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{
+				Reason:  reason,
+				Message: message,
+			},
+		}
+	}
+}
+
 // SetStepStateTerminated sets Terminated state of a step.
 func SetStepStateTerminated(terminated corev1.ContainerStateTerminated) StepStateOp {
 	return func(s *v1beta1.StepState) {
@@ -494,6 +772,20 @@ func SetStepStateWaiting(waiting corev1.ContainerStateWaiting) StepStateOp {
 	}
 }
 
+// StepStateName sets the name of the step for the StepState.
+func StepStateName(name string) StepStateOp {
+	return func(s *v1beta1.StepState) {
+		s.Name = name
+	}
+}
+
+// StepStateImageID sets ImageID of the step for the StepState.
+func StepStateImageID(imageID string) StepStateOp {
+	return func(s *v1beta1.StepState) {
+		s.ImageID = imageID
+	}
+}
+
 // TaskRunOwnerReference sets the OwnerReference, with specified kind and name, to the TaskRun.
 func TaskRunOwnerReference(kind, name string, ops ...OwnerReferenceOp) TaskRunOp {
 	return func(tr *v1beta1.TaskRun) {
@@ -552,6 +844,18 @@ func TaskRunAnnotation(key, value string) TaskRunOp {
 	}
 }
 
+// TaskRunAffinityAssistant sets the name of the Affinity Assistant the TaskRun Pod
+// should be co-scheduled with, as the PipelineRun reconciler does for shared PVC workspaces.
+func TaskRunAffinityAssistant(name string) TaskRunOp {
+	return TaskRunAnnotation(workspace.AnnotationAffinityAssistantName, name)
+}
+
+// TaskRunReadyAnnotation adds the annotation the entrypoint waits on before
+// starting the first step. TaskRun annotations are propagated to the Pod.
+func TaskRunReadyAnnotation() TaskRunOp {
+	return TaskRunAnnotation("tekton.dev/ready", "READY")
+}
+
 // TaskRunSelfLink adds a SelfLink
 func TaskRunSelfLink(selflink string) TaskRunOp {
 	return func(tr *v1beta1.TaskRun) {
@@ -574,6 +878,11 @@ func TaskRunSpec(ops ...TaskRunSpecOp) TaskRunOp {
 	}
 }
 
+// TaskRunNilResources sets the Resources to nil on the TaskRunSpec.
+func TaskRunNilResources(spec *v1beta1.TaskRunSpec) {
+	spec.Resources = nil
+}
+
 // TaskRunCancelled sets the status to cancel to the TaskRunSpec.
 func TaskRunCancelled(spec *v1beta1.TaskRunSpec) {
 	spec.Status = v1beta1.TaskRunSpecStatusCancelled
@@ -632,6 +941,11 @@ func TaskRunTaskSpec(ops ...TaskSpecOp) TaskRunSpecOp {
 	}
 }
 
+// TaskRunEmptyTaskSpec sets an empty inline TaskSpec, without any step, to the TaskRunSpec.
+func TaskRunEmptyTaskSpec(spec *v1beta1.TaskRunSpec) {
+	spec.TaskSpec = &v1beta1.TaskSpec{}
+}
+
 // TaskRunServiceAccountName sets the serviceAccount to the TaskRunSpec.
 func TaskRunServiceAccountName(sa string) TaskRunSpecOp {
 	return func(trs *v1beta1.TaskRunSpec) {
@@ -649,6 +963,12 @@ func TaskRunParam(name, value string, additionalValues ...string) TaskRunSpecOp
 	}
 }
 
+// TaskRunOverrideParam sets a string param on the TaskRunSpec overriding the default
+// declared by TaskWithDefaultParam.
+func TaskRunOverrideParam(param, value string) TaskRunSpecOp {
+	return TaskRunParam(param, value)
+}
+
 // TaskRunResources sets the TaskRunResources to the TaskRunSpec
 func TaskRunResources(ops ...TaskRunResourcesOp) TaskRunSpecOp {
 	return func(spec *v1beta1.TaskRunSpec) {
@@ -675,6 +995,21 @@ func TaskRunResourcesInput(name string, ops ...TaskResourceBindingOp) TaskRunRes
 	}
 }
 
+// TaskRunInputClusterResource adds an input, with specified name, to the TaskRunResources
+// bound to an inline cluster PipelineResourceSpec with the specified url and cadata params.
+func TaskRunInputClusterResource(name, url, cadata string) TaskRunResourcesOp {
+	return TaskRunResourcesInput(name, TaskResourceBindingResourceSpec(&resource.PipelineResourceSpec{
+		Type: resource.PipelineResourceTypeCluster,
+		Params: []resource.ResourceParam{{
+			Name:  "url",
+			Value: url,
+		}, {
+			Name:  "cadata",
+			Value: cadata,
+		}},
+	}))
+}
+
 // TaskRunResourcesOutput adds a TaskRunResource as Outputs to the TaskRunResources
 func TaskRunResourcesOutput(name string, ops ...TaskResourceBindingOp) TaskRunResourcesOp {
 	return func(r *v1beta1.TaskRunResources) {
@@ -690,6 +1025,15 @@ func TaskRunResourcesOutput(name string, ops ...TaskResourceBindingOp) TaskRunRe
 	}
 }
 
+// TaskRunOutputResourceWithPaths adds an output, with specified name, to the TaskRunResources
+// bound to the PipelineResource with the specified name and copied to the specified paths.
+func TaskRunOutputResourceWithPaths(name, resourceRef string, paths ...string) TaskRunResourcesOp {
+	return TaskRunResourcesOutput(name,
+		TaskResourceBindingRef(resourceRef),
+		TaskResourceBindingPaths(paths...),
+	)
+}
+
 // TaskResourceBindingRef set the PipelineResourceRef name to the TaskResourceBinding.
 func TaskResourceBindingRef(name string) TaskResourceBindingOp {
 	return func(b *v1beta1.TaskResourceBinding) {
@@ -727,6 +1071,11 @@ func TaskRunPodTemplate(podTemplate *pod.Template) TaskRunSpecOp {
 	}
 }
 
+// TaskRunNoWorkspaces sets an empty, non-nil, list of workspace bindings to the TaskRunSpec.
+func TaskRunNoWorkspaces(spec *v1beta1.TaskRunSpec) {
+	spec.Workspaces = []v1beta1.WorkspaceBinding{}
+}
+
 // TaskRunWorkspaceEmptyDir adds a workspace binding to an empty dir volume source.
 func TaskRunWorkspaceEmptyDir(name, subPath string) TaskRunSpecOp {
 	return func(spec *v1beta1.TaskRunSpec) {
@@ -738,6 +1087,21 @@ func TaskRunWorkspaceEmptyDir(name, subPath string) TaskRunSpecOp {
 	}
 }
 
+// TaskRunWorkspaceMemoryEmptyDir adds a workspace binding to a memory-backed
+// EmptyDir volume source limited to sizeLimit.
+func TaskRunWorkspaceMemoryEmptyDir(name, subPath string, sizeLimit k8sresource.Quantity) TaskRunSpecOp {
+	return func(spec *v1beta1.TaskRunSpec) {
+		spec.Workspaces = append(spec.Workspaces, v1beta1.WorkspaceBinding{
+			Name:    name,
+			SubPath: subPath,
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium:    corev1.StorageMediumMemory,
+				SizeLimit: &sizeLimit,
+			},
+		})
+	}
+}
+
 // TaskRunWorkspacePVC adds a workspace binding to a PVC volume source.
 func TaskRunWorkspacePVC(name, subPath, claimName string) TaskRunSpecOp {
 	return func(spec *v1beta1.TaskRunSpec) {
@@ -751,6 +1115,22 @@ func TaskRunWorkspacePVC(name, subPath, claimName string) TaskRunSpecOp {
 	}
 }
 
+// TaskRunWorkspacesSharedPVC adds a workspace binding to the same PVC for each entry
+// of names, which maps a workspace name to the subPath it is bound at. Bindings are
+// added in workspace name order.
+func TaskRunWorkspacesSharedPVC(claimName string, names map[string]string) TaskRunSpecOp {
+	return func(spec *v1beta1.TaskRunSpec) {
+		workspaces := make([]string, 0, len(names))
+		for name := range names {
+			workspaces = append(workspaces, name)
+		}
+		sort.Strings(workspaces)
+		for _, name := range workspaces {
+			TaskRunWorkspacePVC(name, names[name], claimName)(spec)
+		}
+	}
+}
+
 // TaskRunWorkspaceVolumeClaimTemplate adds a workspace binding with a VolumeClaimTemplate volume source.
 func TaskRunWorkspaceVolumeClaimTemplate(name, subPath string, volumeClaimTemplate *corev1.PersistentVolumeClaim) TaskRunSpecOp {
 	return func(spec *v1beta1.TaskRunSpec) {
@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -417,3 +418,835 @@ func TestTaskRunWithPodTemplate(t *testing.T) {
 		t.Fatalf("TaskRun diff -want, +got: %v", d)
 	}
 }
+
+func TestTaskScratchVolume(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.Step("myimage", tb.StepName("write")),
+		tb.Step("myimage", tb.StepName("read")),
+		tb.TaskStepTemplate(tb.EnvVar("FRUIT", "BANANA")),
+		tb.TaskScratchVolume("scratch", "/scratch"),
+		tb.Step("myimage", tb.StepName("after")),
+	))
+	scratchMount := []corev1.VolumeMount{{Name: "scratch", MountPath: "/scratch"}}
+	expectedTask := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task"},
+		Spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Container: corev1.Container{
+				Name:         "write",
+				Image:        "myimage",
+				VolumeMounts: scratchMount,
+			}}, {Container: corev1.Container{
+				Name:         "read",
+				Image:        "myimage",
+				VolumeMounts: scratchMount,
+			}}, {Container: corev1.Container{
+				Name:  "after",
+				Image: "myimage",
+			}}},
+			StepTemplate: &corev1.Container{
+				Env:          []corev1.EnvVar{{Name: "FRUIT", Value: "BANANA"}},
+				VolumeMounts: scratchMount,
+			},
+			Volumes: []corev1.Volume{{
+				Name: "scratch",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			}},
+		},
+	}
+	if d := cmp.Diff(expectedTask, task); d != "" {
+		t.Fatalf("Task diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunStatusResolvedImages(t *testing.T) {
+	fetchImage := "gcr.io/foo/fetch@sha256:7ae3d5d1b3e8b0a0e9e0f5a4f6f0d8c5b2c4a2e1d0f9e8d7c6b5a4f3e2d1c0b9"
+	buildImage := "gcr.io/foo/build@sha256:0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c"
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.TaskRunStatusResolvedImages(map[string]string{
+			"fetch": fetchImage,
+			"build": buildImage,
+		}),
+	))
+	expectedStatus := v1beta1.TaskRunStatus{
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			Steps: []v1beta1.StepState{{
+				Name:    "build",
+				ImageID: buildImage,
+			}, {
+				Name:    "fetch",
+				ImageID: fetchImage,
+			}},
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:  "build",
+					Image: buildImage,
+				}}, {Container: corev1.Container{
+					Name:  "fetch",
+					Image: fetchImage,
+				}}},
+			},
+		},
+	}
+	if d := cmp.Diff(expectedStatus, taskRun.Status); d != "" {
+		t.Fatalf("TaskRunStatus diff -want, +got: %v", d)
+	}
+	for i, step := range taskRun.Status.TaskSpec.Steps {
+		state := taskRun.Status.Steps[i]
+		if step.Name != state.Name || step.Image != state.ImageID {
+			t.Errorf("step %q has image %q but step state %q has imageID %q", step.Name, step.Image, state.Name, state.ImageID)
+		}
+	}
+}
+
+func TestStepStateImageID(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.TaskRunStatusTaskSpec(tb.Step("busybox@sha256:abc", tb.StepName("hello"))),
+		tb.StepState(tb.StepStateName("hello"), tb.StepStateImageID("busybox@sha256:abc")),
+	))
+	expectedStatus := v1beta1.TaskRunStatus{
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			Steps: []v1beta1.StepState{{
+				Name:    "hello",
+				ImageID: "busybox@sha256:abc",
+			}},
+			TaskSpec: &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Container: corev1.Container{
+					Name:  "hello",
+					Image: "busybox@sha256:abc",
+				}}},
+			},
+		},
+	}
+	if d := cmp.Diff(expectedStatus, taskRun.Status); d != "" {
+		t.Fatalf("TaskRunStatus diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskWorkspaceDefaultMount(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.TaskWorkspaceDefaultMount("source", "where the sources go"),
+	))
+	expectedWorkspaces := []v1beta1.WorkspaceDeclaration{{
+		Name:        "source",
+		Description: "where the sources go",
+	}}
+	if d := cmp.Diff(expectedWorkspaces, task.Spec.Workspaces); d != "" {
+		t.Fatalf("Workspaces diff -want, +got: %v", d)
+	}
+	if got, want := task.Spec.Workspaces[0].GetMountPath(), "/workspace/source"; got != want {
+		t.Errorf("expected default mount path %q but got %q", want, got)
+	}
+}
+
+func TestTaskRunPodTemplateEnableServiceLinks(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("task"),
+		tb.TaskRunPodTemplateEnableServiceLinks(false),
+	))
+	enableServiceLinks := false
+	expectedPodTemplate := &pod.Template{
+		EnableServiceLinks: &enableServiceLinks,
+	}
+	if d := cmp.Diff(expectedPodTemplate, taskRun.Spec.PodTemplate); d != "" {
+		t.Fatalf("PodTemplate diff -want, +got: %v", d)
+	}
+}
+
+func TestSidecarPort(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.Sidecar("registry", "registry:2", tb.SidecarPort("http", 5000)),
+	))
+	expectedSidecars := []v1beta1.Sidecar{{Container: corev1.Container{
+		Name:  "registry",
+		Image: "registry:2",
+		Ports: []corev1.ContainerPort{{
+			Name:          "http",
+			ContainerPort: 5000,
+			Protocol:      corev1.ProtocolTCP,
+		}},
+	}}}
+	if d := cmp.Diff(expectedSidecars, task.Spec.Sidecars); d != "" {
+		t.Fatalf("Sidecars diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunWorkspaceMemoryEmptyDir(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("task"),
+		tb.TaskRunWorkspaceMemoryEmptyDir("scratch", "tmp", k8sresource.MustParse("256Mi")),
+	))
+	sizeLimit := k8sresource.MustParse("256Mi")
+	expectedWorkspaces := []v1beta1.WorkspaceBinding{{
+		Name:    "scratch",
+		SubPath: "tmp",
+		EmptyDir: &corev1.EmptyDirVolumeSource{
+			Medium:    corev1.StorageMediumMemory,
+			SizeLimit: &sizeLimit,
+		},
+	}}
+	if d := cmp.Diff(expectedWorkspaces, taskRun.Spec.Workspaces); d != "" {
+		t.Fatalf("Workspaces diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunWorkspacesSharedPVC(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("task"),
+		tb.TaskRunWorkspacesSharedPVC("pool-party", map[string]string{
+			"source": "src",
+			"cache":  "cache",
+		}),
+	))
+	expectedWorkspaces := []v1beta1.WorkspaceBinding{{
+		Name:    "cache",
+		SubPath: "cache",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: "pool-party",
+		},
+	}, {
+		Name:    "source",
+		SubPath: "src",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: "pool-party",
+		},
+	}}
+	if d := cmp.Diff(expectedWorkspaces, taskRun.Spec.Workspaces); d != "" {
+		t.Fatalf("Workspaces diff -want, +got: %v", d)
+	}
+}
+
+func TestStepScriptWithArrayParam(t *testing.T) {
+	script := `#!/bin/sh
+for arg in $(params.arr[*]); do
+  echo "$arg"
+done`
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.TaskParam("arr", v1beta1.ParamTypeArray),
+		tb.Step("busybox", tb.StepScript(script)),
+	))
+	if got := task.Spec.Steps[0].Script; got != script {
+		t.Errorf("expected script to be stored unmodified, got %q", got)
+	}
+}
+
+func TestTaskRunPodDeleted(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.TaskRunPodDeleted("test-taskrun-pod"),
+	))
+	expectedStatus := v1beta1.TaskRunStatus{
+		Status: duckv1beta1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: "PodDeleted",
+			}},
+		},
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: "test-taskrun-pod",
+		},
+	}
+	if d := cmp.Diff(expectedStatus, taskRun.Status); d != "" {
+		t.Fatalf("TaskRunStatus diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunNodeLost(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.TaskRunNodeLost("test-taskrun-pod"),
+	))
+	expectedStatus := v1beta1.TaskRunStatus{
+		Status: duckv1beta1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: "NodeLost",
+			}},
+		},
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: "test-taskrun-pod",
+		},
+	}
+	if d := cmp.Diff(expectedStatus, taskRun.Status); d != "" {
+		t.Fatalf("TaskRunStatus diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunOverrideParam(t *testing.T) {
+	task := tb.TaskWithDefaultParam("test-task", "greeting", "hello")
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(
+		tb.TaskRunTaskRef(task.Name),
+		tb.TaskRunOverrideParam("greeting", "bonjour"),
+	))
+	expectedParamSpecs := []v1beta1.ParamSpec{{
+		Name:    "greeting",
+		Type:    v1beta1.ParamTypeString,
+		Default: v1beta1.NewArrayOrString("hello"),
+	}}
+	if d := cmp.Diff(expectedParamSpecs, task.Spec.Params); d != "" {
+		t.Errorf("ParamSpecs diff -want, +got: %v", d)
+	}
+	expectedParams := []v1beta1.Param{{
+		Name:  "greeting",
+		Value: *v1beta1.NewArrayOrString("bonjour"),
+	}}
+	if d := cmp.Diff(expectedParams, taskRun.Spec.Params); d != "" {
+		t.Errorf("Params diff -want, +got: %v", d)
+	}
+}
+
+func TestSidecarPreStopExec(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.Sidecar("cache", "redis", tb.SidecarPreStopExec("redis-cli", "save")),
+	))
+	expectedSidecars := []v1beta1.Sidecar{{Container: corev1.Container{
+		Name:  "cache",
+		Image: "redis",
+		Lifecycle: &corev1.Lifecycle{
+			PreStop: &corev1.Handler{
+				Exec: &corev1.ExecAction{Command: []string{"redis-cli", "save"}},
+			},
+		},
+	}}}
+	if d := cmp.Diff(expectedSidecars, task.Spec.Sidecars); d != "" {
+		t.Fatalf("Sidecars diff -want, +got: %v", d)
+	}
+}
+
+func TestSidecarTerminationMessagePolicy(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.Sidecar("server", "nginx", tb.SidecarTerminationMessagePolicy(corev1.TerminationMessageFallbackToLogsOnError)),
+	))
+	expectedSidecars := []v1beta1.Sidecar{{Container: corev1.Container{
+		Name:                     "server",
+		Image:                    "nginx",
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}}}
+	if d := cmp.Diff(expectedSidecars, task.Spec.Sidecars); d != "" {
+		t.Fatalf("Sidecars diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunResultsOrdered(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.TaskRunResultsOrdered(
+			[2]string{"zebra", "1"},
+			[2]string{"apple", "2"},
+			[2]string{"mango", "3"},
+			[2]string{"banana", "4"},
+		),
+	))
+	expectedResults := []v1beta1.TaskRunResult{
+		{Name: "zebra", Value: "1"},
+		{Name: "apple", Value: "2"},
+		{Name: "mango", Value: "3"},
+		{Name: "banana", Value: "4"},
+	}
+	if d := cmp.Diff(expectedResults, taskRun.Status.TaskRunResults); d != "" {
+		t.Fatalf("TaskRunResults diff -want, +got: %v", d)
+	}
+}
+
+func TestStepTemplateResources(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.TaskStepTemplate(tb.StepTemplateResources(
+			corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("100m")},
+			corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("500m")},
+		)),
+		tb.Step("myimage", tb.StepName("default")),
+		tb.Step("myimage", tb.StepName("greedy"), tb.StepResources(
+			tb.Requests(tb.CPU("1")),
+			tb.Limits(tb.CPU("2")),
+		)),
+	))
+	resourceQuantityCmp := cmp.Comparer(func(x, y k8sresource.Quantity) bool {
+		return x.Cmp(y) == 0
+	})
+	expectedTemplateResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("100m")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("500m")},
+	}
+	if d := cmp.Diff(expectedTemplateResources, task.Spec.StepTemplate.Resources, resourceQuantityCmp); d != "" {
+		t.Errorf("StepTemplate resources diff -want, +got: %v", d)
+	}
+
+	steps, err := v1beta1.MergeStepsWithStepTemplate(task.Spec.StepTemplate, task.Spec.Steps)
+	if err != nil {
+		t.Fatalf("unexpected error merging steps with step template: %v", err)
+	}
+	if d := cmp.Diff(expectedTemplateResources, steps[0].Resources, resourceQuantityCmp); d != "" {
+		t.Errorf("Step %q resources diff -want, +got: %v", steps[0].Name, d)
+	}
+	expectedStepResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("1")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("2")},
+	}
+	if d := cmp.Diff(expectedStepResources, steps[1].Resources, resourceQuantityCmp); d != "" {
+		t.Errorf("Step %q resources diff -want, +got: %v", steps[1].Name, d)
+	}
+}
+
+func TestTaskRunStatusStepsMatching(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.TaskRunStatusStepsMatching("clone", "build", "push"),
+	))
+	var expectedSteps []v1beta1.StepState
+	for _, name := range []string{"clone", "build", "push"} {
+		expectedSteps = append(expectedSteps, v1beta1.StepState{
+			Name: name,
+			ContainerState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+			},
+		})
+	}
+	if d := cmp.Diff(expectedSteps, taskRun.Status.Steps); d != "" {
+		t.Fatalf("Steps diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunNoWorkspaces(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("task"),
+		tb.TaskRunNoWorkspaces,
+	))
+	if taskRun.Spec.Workspaces == nil {
+		t.Fatal("expected workspaces to be non-nil")
+	}
+	if len(taskRun.Spec.Workspaces) != 0 {
+		t.Errorf("expected no workspaces but got %d", len(taskRun.Spec.Workspaces))
+	}
+}
+
+func TestRetryWithPod(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.RetryWithPod("test-taskrun-pod-retry1", "Failed"),
+		tb.RetryWithPod("test-taskrun-pod-retry2", "TaskRunTimeout"),
+	))
+	expectedRetries := []v1beta1.TaskRunStatus{{
+		Status: duckv1beta1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: "Failed",
+			}},
+		},
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: "test-taskrun-pod-retry1",
+		},
+	}, {
+		Status: duckv1beta1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: "TaskRunTimeout",
+			}},
+		},
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: "test-taskrun-pod-retry2",
+		},
+	}}
+	if d := cmp.Diff(expectedRetries, taskRun.Status.RetriesStatus); d != "" {
+		t.Fatalf("RetriesStatus diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunRetriedThenSucceeded(t *testing.T) {
+	completionTime := time.Date(2020, time.October, 1, 12, 0, 0, 0, time.UTC)
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.TaskRunRetriedThenSucceeded("Failed", completionTime),
+	))
+	expectedStatus := v1beta1.TaskRunStatus{
+		Status: duckv1beta1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+				Reason: "Succeeded",
+			}},
+		},
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			CompletionTime: &metav1.Time{Time: completionTime},
+			RetriesStatus: []v1beta1.TaskRunStatus{{
+				Status: duckv1beta1.Status{
+					Conditions: []apis.Condition{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionFalse,
+						Reason: "Failed",
+					}},
+				},
+			}},
+		},
+	}
+	if d := cmp.Diff(expectedStatus, taskRun.Status); d != "" {
+		t.Fatalf("TaskRunStatus diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunInputClusterResource(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("deploy"),
+		tb.TaskRunResources(
+			tb.TaskRunInputClusterResource("target-cluster", "https://10.10.10.10", "LS0tLS1CRUdJTiBDRVJ"),
+		),
+	))
+	expectedResources := &v1beta1.TaskRunResources{
+		Inputs: []v1beta1.TaskResourceBinding{{
+			PipelineResourceBinding: v1beta1.PipelineResourceBinding{
+				Name: "target-cluster",
+				ResourceSpec: &resource.PipelineResourceSpec{
+					Type: resource.PipelineResourceTypeCluster,
+					Params: []resource.ResourceParam{{
+						Name:  "url",
+						Value: "https://10.10.10.10",
+					}, {
+						Name:  "cadata",
+						Value: "LS0tLS1CRUdJTiBDRVJ",
+					}},
+				},
+			},
+		}},
+	}
+	if d := cmp.Diff(expectedResources, taskRun.Spec.Resources); d != "" {
+		t.Fatalf("Resources diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunStatusTaskSpecWorkspaces(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.TaskRunStatusTaskSpec(tb.Step("busybox")),
+		tb.TaskRunStatusTaskSpecWorkspaces("source", "cache"),
+	))
+	expectedTaskSpec := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{Container: corev1.Container{
+			Image: "busybox",
+		}}},
+		Workspaces: []v1beta1.WorkspaceDeclaration{
+			{Name: "source"},
+			{Name: "cache"},
+		},
+	}
+	if d := cmp.Diff(expectedTaskSpec, taskRun.Status.TaskSpec); d != "" {
+		t.Fatalf("TaskSpec diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunAffinityAssistant(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunAffinityAssistant("affinity-assistant-0123456789"))
+	expectedAnnotations := map[string]string{
+		"pipeline.tekton.dev/affinity-assistant": "affinity-assistant-0123456789",
+	}
+	if d := cmp.Diff(expectedAnnotations, taskRun.Annotations); d != "" {
+		t.Fatalf("Annotations diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunReadyAnnotation(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunReadyAnnotation())
+	expectedAnnotations := map[string]string{
+		"tekton.dev/ready": "READY",
+	}
+	if d := cmp.Diff(expectedAnnotations, taskRun.Annotations); d != "" {
+		t.Fatalf("Annotations diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunEmptyTaskSpec(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(tb.TaskRunEmptyTaskSpec))
+	if taskRun.Spec.TaskSpec == nil {
+		t.Fatal("expected an inline TaskSpec")
+	}
+	if len(taskRun.Spec.TaskSpec.Steps) != 0 {
+		t.Errorf("expected no steps but got %d", len(taskRun.Spec.TaskSpec.Steps))
+	}
+}
+
+func TestTaskRunCancelledStatus(t *testing.T) {
+	completionTime := time.Date(2020, time.October, 1, 12, 0, 0, 0, time.UTC)
+	taskRun := tb.TaskRun("test-taskrun",
+		tb.TaskRunSpec(tb.TaskRunTaskRef("task"), tb.TaskRunCancelled),
+		tb.TaskRunStatus(tb.TaskRunCancelledStatus(completionTime)),
+	)
+	expectedStatus := v1beta1.TaskRunStatus{
+		Status: duckv1beta1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: "TaskRunCancelled",
+			}},
+		},
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			CompletionTime: &metav1.Time{Time: completionTime},
+		},
+	}
+	if d := cmp.Diff(expectedStatus, taskRun.Status); d != "" {
+		t.Fatalf("TaskRunStatus diff -want, +got: %v", d)
+	}
+}
+
+func TestStepTemplateImagePullPolicy(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.TaskStepTemplate(tb.StepTemplateImagePullPolicy(corev1.PullIfNotPresent)),
+		tb.Step("myimage", tb.StepName("inherit")),
+		tb.Step("myimage:latest", tb.StepName("override"), tb.StepImagePullPolicy(corev1.PullAlways)),
+	))
+	if got := task.Spec.StepTemplate.ImagePullPolicy; got != corev1.PullIfNotPresent {
+		t.Errorf("expected step template pull policy %q but got %q", corev1.PullIfNotPresent, got)
+	}
+
+	steps, err := v1beta1.MergeStepsWithStepTemplate(task.Spec.StepTemplate, task.Spec.Steps)
+	if err != nil {
+		t.Fatalf("unexpected error merging steps with step template: %v", err)
+	}
+	for i, want := range []corev1.PullPolicy{corev1.PullIfNotPresent, corev1.PullAlways} {
+		if got := steps[i].ImagePullPolicy; got != want {
+			t.Errorf("expected step %q pull policy %q but got %q", steps[i].Name, want, got)
+		}
+	}
+}
+
+func TestStepTerminationMessagePath(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.Step("myimage", tb.StepTerminationMessagePath("/tekton/custom-termination")),
+	))
+	if got, want := task.Spec.Steps[0].TerminationMessagePath, "/tekton/custom-termination"; got != want {
+		t.Errorf("expected step termination message path %q but got %q", want, got)
+	}
+}
+
+func TestStepImagePullPolicy(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.TaskStepTemplate(tb.StepTemplateImagePullPolicy(corev1.PullAlways)),
+		tb.Step("local/myimage", tb.StepImagePullPolicy(corev1.PullNever)),
+	))
+	if got := task.Spec.Steps[0].ImagePullPolicy; got != corev1.PullNever {
+		t.Errorf("expected step pull policy %q but got %q", corev1.PullNever, got)
+	}
+
+	steps, err := v1beta1.MergeStepsWithStepTemplate(task.Spec.StepTemplate, task.Spec.Steps)
+	if err != nil {
+		t.Fatalf("unexpected error merging steps with step template: %v", err)
+	}
+	if got := steps[0].ImagePullPolicy; got != corev1.PullNever {
+		t.Errorf("expected merged step pull policy %q but got %q", corev1.PullNever, got)
+	}
+}
+
+func TestStepArgsWithContextVariables(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.Step("busybox", tb.StepArgs("--run=$(context.taskRun.name)", "--task=$(context.task.name)")),
+	))
+	expectedArgs := []string{"--run=$(context.taskRun.name)", "--task=$(context.task.name)"}
+	if d := cmp.Diff(expectedArgs, task.Spec.Steps[0].Args); d != "" {
+		t.Fatalf("Args diff -want, +got: %v", d)
+	}
+}
+
+func TestStepStateOOMKilled(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.StepState(tb.StepStateOOMKilled("build")),
+	))
+	expectedSteps := []v1beta1.StepState{{
+		Name: "build",
+		ContainerState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 137,
+				Reason:   "OOMKilled",
+			},
+		},
+	}}
+	if d := cmp.Diff(expectedSteps, taskRun.Status.Steps); d != "" {
+		t.Fatalf("Steps diff -want, +got: %v", d)
+	}
+}
+
+func TestStepStateWaitingReason(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.StepState(tb.StepStateWaitingReason("build", "ImagePullBackOff", `Back-off pulling image "myimage"`)),
+	))
+	expectedSteps := []v1beta1.StepState{{
+		Name: "build",
+		ContainerState: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{
+				Reason:  "ImagePullBackOff",
+				Message: `Back-off pulling image "myimage"`,
+			},
+		},
+	}}
+	if d := cmp.Diff(expectedSteps, taskRun.Status.Steps); d != "" {
+		t.Fatalf("Steps diff -want, +got: %v", d)
+	}
+}
+
+func TestStepResourcesRequests(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.Step("myimage", tb.StepResourcesRequests(corev1.ResourceList{
+			corev1.ResourceMemory: k8sresource.MustParse("1Gi"),
+		})),
+	))
+	resources := task.Spec.Steps[0].Resources
+	if got, want := resources.Requests[corev1.ResourceMemory], k8sresource.MustParse("1Gi"); got.Cmp(want) != 0 {
+		t.Errorf("expected memory request %s but got %s", want.String(), got.String())
+	}
+	if resources.Limits != nil {
+		t.Errorf("expected no limits but got %v", resources.Limits)
+	}
+}
+
+func TestSidecarSecretVolumeMount(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.TaskVolume("registry-creds", tb.VolumeSourceSecret("registry-secret")),
+		tb.Sidecar("proxy", "registry-proxy", tb.VolumeMount("registry-creds", "/etc/creds")),
+	))
+	expectedVolumes := []corev1.Volume{{
+		Name: "registry-creds",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "registry-secret"},
+		},
+	}}
+	if d := cmp.Diff(expectedVolumes, task.Spec.Volumes); d != "" {
+		t.Errorf("Volumes diff -want, +got: %v", d)
+	}
+	expectedMounts := []corev1.VolumeMount{{
+		Name:      "registry-creds",
+		MountPath: "/etc/creds",
+	}}
+	if d := cmp.Diff(expectedMounts, task.Spec.Sidecars[0].VolumeMounts); d != "" {
+		t.Errorf("Sidecar volume mounts diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunOutputResourceWithPaths(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("build"),
+		tb.TaskRunResources(
+			tb.TaskRunOutputResourceWithPaths("workspace", "my-git", "/pvc/build/workspace", "/pvc/test/workspace"),
+		),
+	))
+	expectedOutputs := []v1beta1.TaskResourceBinding{{
+		PipelineResourceBinding: v1beta1.PipelineResourceBinding{
+			Name:        "workspace",
+			ResourceRef: &v1beta1.PipelineResourceRef{Name: "my-git"},
+		},
+		Paths: []string{"/pvc/build/workspace", "/pvc/test/workspace"},
+	}}
+	if d := cmp.Diff(expectedOutputs, taskRun.Spec.Resources.Outputs); d != "" {
+		t.Fatalf("Outputs diff -want, +got: %v", d)
+	}
+}
+
+func TestStepScriptWithoutShebang(t *testing.T) {
+	script := "echo hello\necho world"
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.Step("busybox", tb.StepScript(script)),
+	))
+	// The default shebang is added when converting to a Pod, not by the builder.
+	if got := task.Spec.Steps[0].Script; got != script {
+		t.Errorf("expected script to be stored unmodified, got %q", got)
+	}
+}
+
+func TestTaskRunNilResources(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("task"),
+		tb.TaskRunNilResources,
+	))
+	if taskRun.Spec.Resources != nil {
+		t.Errorf("expected nil resources but got %v", taskRun.Spec.Resources)
+	}
+}
+
+func TestTaskRunPendingResourceQuota(t *testing.T) {
+	message := "TaskRun Pod exceeded available resources: exceeded quota: compute-resources"
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunStatus(
+		tb.TaskRunPendingResourceQuota(message),
+	))
+	expectedConditions := duckv1beta1.Conditions{{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  "ExceededResourceQuota",
+		Message: message,
+	}}
+	if d := cmp.Diff(expectedConditions, taskRun.Status.Conditions); d != "" {
+		t.Fatalf("Conditions diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskRunPodTemplateAutomountSAToken(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("task"),
+		tb.TaskRunNodeSelector(map[string]string{"label": "value"}),
+		tb.TaskRunPodTemplateAutomountSAToken(false),
+	))
+	automountServiceAccountToken := false
+	expectedPodTemplate := &pod.Template{
+		NodeSelector:                 map[string]string{"label": "value"},
+		AutomountServiceAccountToken: &automountServiceAccountToken,
+	}
+	if d := cmp.Diff(expectedPodTemplate, taskRun.Spec.PodTemplate); d != "" {
+		t.Fatalf("PodTemplate diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskDocumented(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.TaskDescription("Builds and pushes an image."),
+		tb.TaskParam("image", v1beta1.ParamTypeString, tb.ParamSpecDescription("Reference of the image to build.")),
+		tb.TaskResults("digest", "Digest of the pushed image."),
+	))
+	if d := cmp.Diff("Builds and pushes an image.", task.Spec.Description); d != "" {
+		t.Errorf("Description diff -want, +got: %v", d)
+	}
+	expectedParams := []v1beta1.ParamSpec{{
+		Name:        "image",
+		Type:        v1beta1.ParamTypeString,
+		Description: "Reference of the image to build.",
+	}}
+	if d := cmp.Diff(expectedParams, task.Spec.Params); d != "" {
+		t.Errorf("Params diff -want, +got: %v", d)
+	}
+	expectedResults := []v1beta1.TaskResult{{
+		Name:        "digest",
+		Description: "Digest of the pushed image.",
+	}}
+	if d := cmp.Diff(expectedResults, task.Spec.Results); d != "" {
+		t.Errorf("Results diff -want, +got: %v", d)
+	}
+}
+
+func TestTaskOrderedSteps(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.TaskOrderedSteps("alpine", "busybox", "ubuntu"),
+	))
+	expectedSteps := []v1beta1.Step{
+		{Container: corev1.Container{Name: "step-0", Image: "alpine"}},
+		{Container: corev1.Container{Name: "step-1", Image: "busybox"}},
+		{Container: corev1.Container{Name: "step-2", Image: "ubuntu"}},
+	}
+	if d := cmp.Diff(expectedSteps, task.Spec.Steps); d != "" {
+		t.Fatalf("Steps diff -want, +got: %v", d)
+	}
+}
+
+func TestStepArgsArrayParam(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.TaskParam("flags", v1beta1.ParamTypeArray),
+		tb.Step("busybox", tb.StepArgs("--verbose"), tb.StepArgsArrayParam("flags")),
+	))
+	expectedArgs := []string{"--verbose", "$(params.flags[*])"}
+	if d := cmp.Diff(expectedArgs, task.Spec.Steps[0].Args); d != "" {
+		t.Fatalf("Args diff -want, +got: %v", d)
+	}
+}
+
+func TestStepVolumeDevice(t *testing.T) {
+	task := tb.Task("test-task", tb.TaskSpec(
+		tb.Step("myimage", tb.StepVolumeDevice("disk", "/dev/xvda")),
+	))
+	expectedDevices := []corev1.VolumeDevice{{
+		Name:       "disk",
+		DevicePath: "/dev/xvda",
+	}}
+	if d := cmp.Diff(expectedDevices, task.Spec.Steps[0].VolumeDevices); d != "" {
+		t.Fatalf("VolumeDevices diff -want, +got: %v", d)
+	}
+}